@@ -36,6 +36,8 @@ import (
 	"k8s.io/kubernetes/pkg/scheduler/apis/config/testing/defaults"
 
 	"Load-Aware-Scheduler-Plugin/plugin/LoadAware"
+	loadawareconfig "Load-Aware-Scheduler-Plugin/plugin/LoadAware/apis/config"
+	"Load-Aware-Scheduler-Plugin/plugin/LoadAware/apis/config/v1beta3"
 )
 
 func TestSetup(t *testing.T) {
@@ -94,12 +96,13 @@ profiles:
   pluginConfig:
   - name: NodeResourcesAllocatable
     args:
-      mode: Least
-      resources:
-      - name: cpu
-        weight: 1000000
-      - name: memory
-        weight: 1
+      scoringStrategy:
+        type: LeastAllocated
+        resources:
+        - name: cpu
+          weight: 1000000
+        - name: memory
+          weight: 1
 `, configKubeconfig)), os.FileMode(0600)); err != nil {
 		t.Fatal(err)
 	}
@@ -211,3 +214,46 @@ profiles:
 		})
 	}
 }
+
+// TestLoadAwareArgsRoundTrip checks that converting a v1beta3.LoadAwareArgs to the internal
+// config.LoadAwareArgs and back doesn't lose or mangle any field.
+func TestLoadAwareArgsRoundTrip(t *testing.T) {
+	in := &v1beta3.LoadAwareArgs{
+		ScoringStrategy: &v1beta3.ScoringStrategy{
+			Type: v1beta3.MostAllocated,
+			Resources: []v1beta3.ResourceSpec{
+				{Name: "cpu", Weight: 1000000},
+				{Name: "memory", Weight: 1},
+			},
+		},
+		MetricsProvider: "prometheus",
+		Prometheus: v1beta3.PrometheusConfig{
+			Address:               "http://prometheus.monitoring:9090",
+			NodeLabel:             "node",
+			Queries:               map[string]string{"cpu": "sum(rate(node_cpu_seconds_total[1m])) by (node)"},
+			ScrapeIntervalSeconds: 30,
+		},
+		EWMAAlpha:                0.5,
+		HotSpotThresholds:        map[string]int64{"cpu": 85},
+		AssumedLoadTTLMultiplier: 2,
+		HardCaps:                 map[string]int64{"cpu": 90},
+		QosLabelKey:              "qos.example.io/level",
+		QosResourceMappings: []v1beta3.QosResourceMapping{
+			{QosLabel: "best-effort", CPUResource: "reclaimed_millicpu", MemResource: "reclaimed_memory"},
+		},
+	}
+
+	internal := &loadawareconfig.LoadAwareArgs{}
+	if err := v1beta3.Convert_v1beta3_LoadAwareArgs_To_config_LoadAwareArgs(in, internal); err != nil {
+		t.Fatalf("converting to internal args: %v", err)
+	}
+
+	out := &v1beta3.LoadAwareArgs{}
+	if err := v1beta3.Convert_config_LoadAwareArgs_To_v1beta3_LoadAwareArgs(internal, out); err != nil {
+		t.Fatalf("converting back to v1beta3 args: %v", err)
+	}
+
+	if diff := cmp.Diff(in, out); diff != "" {
+		t.Errorf("round trip through config.LoadAwareArgs changed the args (-in, +out): %s", diff)
+	}
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	kubeschedulerscheme "k8s.io/kubernetes/pkg/scheduler/apis/config/scheme"
+
+	"Load-Aware-Scheduler-Plugin/plugin/LoadAware/apis/config/v1beta3"
+)
+
+// init registers LoadAwareArgs (internal and v1beta3) into kube-scheduler's own config
+// scheme, the same scheme in-tree plugins like NodeResourcesFit use. This lets the
+// framework decode this plugin's pluginConfig block straight into *LoadAwareArgs before
+// calling NewAllocatable, instead of handing it a raw, plugin-decoded blob.
+func init() {
+	utilruntime.Must(AddToScheme(kubeschedulerscheme.Scheme))
+	utilruntime.Must(v1beta3.AddToScheme(kubeschedulerscheme.Scheme))
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config holds the internal (versionless) API for the NodeResourcesAllocatable
+// plugin's arguments, following the same types/{version}/validation split as
+// k8s.io/kubernetes/pkg/scheduler/apis/config; scheme registration is in install.go.
+package config
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScoringStrategyType is the type of a ScoringStrategy.
+type ScoringStrategyType string
+
+const (
+	// LeastAllocated favors nodes with fewer requested resources.
+	LeastAllocated ScoringStrategyType = "LeastAllocated"
+	// MostAllocated favors nodes with more requested resources.
+	MostAllocated ScoringStrategyType = "MostAllocated"
+	// BalancedAllocation favors nodes with balanced resource usage.
+	BalancedAllocation ScoringStrategyType = "BalancedAllocation"
+	// RequestedToCapacityRatio scores nodes against operator-supplied utilization shapes.
+	RequestedToCapacityRatio ScoringStrategyType = "RequestedToCapacityRatio"
+)
+
+// LoadAwareArgs holds arguments used to configure the NodeResourcesAllocatable plugin.
+type LoadAwareArgs struct {
+	metav1.TypeMeta
+
+	// ScoringStrategy selects the resource scoring heuristic and its per-resource weights.
+	ScoringStrategy *ScoringStrategy
+
+	// MetricsProvider selects where per-node usage comes from: metrics-server, prometheus,
+	// or hybrid.
+	MetricsProvider string
+	// Prometheus configures the optional Prometheus metrics source.
+	Prometheus PrometheusConfig
+	// EWMAAlpha is the weight given to each new sample when smoothing across scrape
+	// intervals.
+	EWMAAlpha float64
+	// HotSpotThresholds maps a resource name to the smoothed utilization percentage at or
+	// above which a node is scored near-zero.
+	HotSpotThresholds map[string]int64
+
+	// AssumedLoadTTLMultiplier is how many multiples of the metrics scrape interval a
+	// Reserve'd pod's request stays counted as assumed load.
+	AssumedLoadTTLMultiplier float64
+	// HardCaps maps a resource name to the projected-utilization percentage above which
+	// Filter rejects a node outright.
+	HardCaps map[string]int64
+
+	// PodResourcesEndpoint is the kubelet pod-resources gRPC socket to poll for
+	// device-level allocation state.
+	PodResourcesEndpoint string
+	// DeviceAware enables blending free-device-topology signal into the node score.
+	DeviceAware bool
+
+	// QosLabelKey is the pod label checked against QosResourceMappings.
+	QosLabelKey string
+	// QosResourceMappings lets pods carrying a matching QosLabelKey value be scored
+	// against reclaimed-pool capacity instead of nominal node allocatable.
+	QosResourceMappings []QosResourceMapping
+}
+
+// ScoringStrategy defines the scoring strategy for the plugin, mirroring
+// NodeResourcesFitArgs.ScoringStrategy from kube-scheduler's built-in NodeResourcesFit
+// plugin.
+type ScoringStrategy struct {
+	// Type selects which strategy to run.
+	Type ScoringStrategyType
+
+	// Resources lists the resources to score and their relative weight.
+	Resources []ResourceSpec
+
+	// RequestedToCapacityRatio configures the shape function used when Type is
+	// RequestedToCapacityRatio.
+	RequestedToCapacityRatio *RequestedToCapacityRatioParam
+}
+
+// ResourceSpec names a resource and how heavily it should count towards a node's score.
+type ResourceSpec struct {
+	// Name is the resource name, e.g. "cpu" or "memory".
+	Name string
+	// Weight is the relative weight given to this resource. Must be positive.
+	Weight int64
+}
+
+// RequestedToCapacityRatioParam holds the utilization->score shape used by
+// the RequestedToCapacityRatio scoring strategy.
+type RequestedToCapacityRatioParam struct {
+	// Shape is the piecewise-linear utilization->score function shared by every resource
+	// scored under this strategy.
+	Shape []UtilizationShapePoint
+}
+
+// UtilizationShapePoint is one point of a piecewise-linear utilization->score function.
+type UtilizationShapePoint struct {
+	// Utilization is a percentage of allocatable, in [0, 100].
+	Utilization int32
+	// Score is the score assigned at this utilization, in [0, 10].
+	Score int32
+}
+
+// PrometheusConfig configures the optional Prometheus metrics source.
+type PrometheusConfig struct {
+	// Address is the base URL of the Prometheus server.
+	Address string
+	// NodeLabel is the label on the PromQL result that carries the node name.
+	NodeLabel string
+	// Queries maps a resource name to the PromQL expression used to sample its usage.
+	Queries map[string]string
+	// ScrapeIntervalSeconds is how often the configured queries are re-evaluated.
+	ScrapeIntervalSeconds int64
+}
+
+// QosResourceMapping associates a pod QoS label value with the scalar ("reclaimed")
+// resources that represent spare capacity left over by guaranteed workloads.
+type QosResourceMapping struct {
+	// QosLabel is the pod label value that selects this mapping.
+	QosLabel string
+	// CPUResource is the scalar resource name reporting reclaimable millicores.
+	CPUResource string
+	// MemResource is the scalar resource name reporting reclaimable memory bytes.
+	MemResource string
+}
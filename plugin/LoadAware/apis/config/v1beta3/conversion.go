@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta3
+
+import (
+	config "Load-Aware-Scheduler-Plugin/plugin/LoadAware/apis/config"
+)
+
+// Convert_v1beta3_LoadAwareArgs_To_config_LoadAwareArgs converts the external, decoded
+// (and defaulted) args into the internal representation the plugin constructor consumes.
+func Convert_v1beta3_LoadAwareArgs_To_config_LoadAwareArgs(in *LoadAwareArgs, out *config.LoadAwareArgs) error {
+	out.TypeMeta = in.TypeMeta
+	if in.ScoringStrategy != nil {
+		out.ScoringStrategy = &config.ScoringStrategy{
+			Type: config.ScoringStrategyType(in.ScoringStrategy.Type),
+		}
+		for _, r := range in.ScoringStrategy.Resources {
+			out.ScoringStrategy.Resources = append(out.ScoringStrategy.Resources, config.ResourceSpec{Name: r.Name, Weight: r.Weight})
+		}
+		if in.ScoringStrategy.RequestedToCapacityRatio != nil {
+			param := &config.RequestedToCapacityRatioParam{}
+			for _, p := range in.ScoringStrategy.RequestedToCapacityRatio.Shape {
+				param.Shape = append(param.Shape, config.UtilizationShapePoint{Utilization: p.Utilization, Score: p.Score})
+			}
+			out.ScoringStrategy.RequestedToCapacityRatio = param
+		}
+	}
+
+	out.MetricsProvider = in.MetricsProvider
+	out.Prometheus = config.PrometheusConfig{
+		Address:               in.Prometheus.Address,
+		NodeLabel:             in.Prometheus.NodeLabel,
+		ScrapeIntervalSeconds: in.Prometheus.ScrapeIntervalSeconds,
+	}
+	if in.Prometheus.Queries != nil {
+		out.Prometheus.Queries = make(map[string]string, len(in.Prometheus.Queries))
+		for k, v := range in.Prometheus.Queries {
+			out.Prometheus.Queries[k] = v
+		}
+	}
+	out.EWMAAlpha = in.EWMAAlpha
+	out.HotSpotThresholds = in.HotSpotThresholds
+	out.AssumedLoadTTLMultiplier = in.AssumedLoadTTLMultiplier
+	out.HardCaps = in.HardCaps
+	out.PodResourcesEndpoint = in.PodResourcesEndpoint
+	out.DeviceAware = in.DeviceAware
+	out.QosLabelKey = in.QosLabelKey
+	for _, m := range in.QosResourceMappings {
+		out.QosResourceMappings = append(out.QosResourceMappings, config.QosResourceMapping{
+			QosLabel:    m.QosLabel,
+			CPUResource: m.CPUResource,
+			MemResource: m.MemResource,
+		})
+	}
+	return nil
+}
+
+// Convert_config_LoadAwareArgs_To_v1beta3_LoadAwareArgs is the reverse conversion, used by
+// the round-trip test and by anything that needs to re-encode internal args.
+func Convert_config_LoadAwareArgs_To_v1beta3_LoadAwareArgs(in *config.LoadAwareArgs, out *LoadAwareArgs) error {
+	out.TypeMeta = in.TypeMeta
+	if in.ScoringStrategy != nil {
+		out.ScoringStrategy = &ScoringStrategy{
+			Type: ScoringStrategyType(in.ScoringStrategy.Type),
+		}
+		for _, r := range in.ScoringStrategy.Resources {
+			out.ScoringStrategy.Resources = append(out.ScoringStrategy.Resources, ResourceSpec{Name: r.Name, Weight: r.Weight})
+		}
+		if in.ScoringStrategy.RequestedToCapacityRatio != nil {
+			param := &RequestedToCapacityRatioParam{}
+			for _, p := range in.ScoringStrategy.RequestedToCapacityRatio.Shape {
+				param.Shape = append(param.Shape, UtilizationShapePoint{Utilization: p.Utilization, Score: p.Score})
+			}
+			out.ScoringStrategy.RequestedToCapacityRatio = param
+		}
+	}
+
+	out.MetricsProvider = in.MetricsProvider
+	out.Prometheus = PrometheusConfig{
+		Address:               in.Prometheus.Address,
+		NodeLabel:             in.Prometheus.NodeLabel,
+		ScrapeIntervalSeconds: in.Prometheus.ScrapeIntervalSeconds,
+	}
+	if in.Prometheus.Queries != nil {
+		out.Prometheus.Queries = make(map[string]string, len(in.Prometheus.Queries))
+		for k, v := range in.Prometheus.Queries {
+			out.Prometheus.Queries[k] = v
+		}
+	}
+	out.EWMAAlpha = in.EWMAAlpha
+	out.HotSpotThresholds = in.HotSpotThresholds
+	out.AssumedLoadTTLMultiplier = in.AssumedLoadTTLMultiplier
+	out.HardCaps = in.HardCaps
+	out.PodResourcesEndpoint = in.PodResourcesEndpoint
+	out.DeviceAware = in.DeviceAware
+	out.QosLabelKey = in.QosLabelKey
+	for _, m := range in.QosResourceMappings {
+		out.QosResourceMappings = append(out.QosResourceMappings, QosResourceMapping{
+			QosLabel:    m.QosLabel,
+			CPUResource: m.CPUResource,
+			MemResource: m.MemResource,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta3
+
+import "k8s.io/apimachinery/pkg/runtime"
+
+// DeepCopyObject implements runtime.Object.
+func (in *LoadAwareArgs) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// DeepCopy returns a deep copy of in.
+func (in *LoadAwareArgs) DeepCopy() *LoadAwareArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadAwareArgs)
+	*out = *in
+	if in.ScoringStrategy != nil {
+		out.ScoringStrategy = in.ScoringStrategy.DeepCopy()
+	}
+	if in.Prometheus.Queries != nil {
+		out.Prometheus.Queries = make(map[string]string, len(in.Prometheus.Queries))
+		for k, v := range in.Prometheus.Queries {
+			out.Prometheus.Queries[k] = v
+		}
+	}
+	if in.HotSpotThresholds != nil {
+		out.HotSpotThresholds = make(map[string]int64, len(in.HotSpotThresholds))
+		for k, v := range in.HotSpotThresholds {
+			out.HotSpotThresholds[k] = v
+		}
+	}
+	if in.HardCaps != nil {
+		out.HardCaps = make(map[string]int64, len(in.HardCaps))
+		for k, v := range in.HardCaps {
+			out.HardCaps[k] = v
+		}
+	}
+	if in.QosResourceMappings != nil {
+		out.QosResourceMappings = make([]QosResourceMapping, len(in.QosResourceMappings))
+		copy(out.QosResourceMappings, in.QosResourceMappings)
+	}
+	return out
+}
+
+// DeepCopy returns a deep copy of in.
+func (in *ScoringStrategy) DeepCopy() *ScoringStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ScoringStrategy)
+	out.Type = in.Type
+	if in.Resources != nil {
+		out.Resources = make([]ResourceSpec, len(in.Resources))
+		copy(out.Resources, in.Resources)
+	}
+	if in.RequestedToCapacityRatio != nil {
+		out.RequestedToCapacityRatio = new(RequestedToCapacityRatioParam)
+		if in.RequestedToCapacityRatio.Shape != nil {
+			out.RequestedToCapacityRatio.Shape = make([]UtilizationShapePoint, len(in.RequestedToCapacityRatio.Shape))
+			copy(out.RequestedToCapacityRatio.Shape, in.RequestedToCapacityRatio.Shape)
+		}
+	}
+	return out
+}
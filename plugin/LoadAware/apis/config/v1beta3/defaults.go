@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta3
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// defaultEWMAAlpha, defaultAssumedLoadTTLMultiplier, defaultScrapeIntervalSeconds and
+// defaultQosLabelKey mirror the zero-value defaults the plugin has always applied at
+// construction time; they're hoisted here so defaulting happens at decode time.
+// NewAllocatable still falls back to its own defaults for any of these left at zero.
+const (
+	defaultEWMAAlpha                = 0.5
+	defaultAssumedLoadTTLMultiplier = 2
+	defaultScrapeIntervalSeconds    = 30
+	defaultNodeLabel                = "node"
+	defaultQosLabelKey              = "qos.example.io/level"
+)
+
+// defaultCPUWeight and defaultMemoryWeight match metrics_obtain.go's
+// defaultResourcesToWeightMap.
+const (
+	defaultCPUWeight    = 1 << 20
+	defaultMemoryWeight = 1
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// RegisterDefaults registers SetDefaults_LoadAwareArgs with scheme.
+func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&LoadAwareArgs{}, func(obj interface{}) { SetDefaults_LoadAwareArgs(obj.(*LoadAwareArgs)) })
+	return nil
+}
+
+// SetDefaults_LoadAwareArgs sets the default values a zero-valued field should take,
+// matching what the plugin constructor used to assume when args were decoded ad hoc.
+func SetDefaults_LoadAwareArgs(obj *LoadAwareArgs) {
+	if obj.ScoringStrategy == nil {
+		obj.ScoringStrategy = &ScoringStrategy{}
+	}
+	if obj.ScoringStrategy.Type == "" {
+		obj.ScoringStrategy.Type = LeastAllocated
+	}
+	if len(obj.ScoringStrategy.Resources) == 0 {
+		obj.ScoringStrategy.Resources = []ResourceSpec{
+			{Name: "cpu", Weight: defaultCPUWeight},
+			{Name: "memory", Weight: defaultMemoryWeight},
+		}
+	}
+	for i := range obj.ScoringStrategy.Resources {
+		if obj.ScoringStrategy.Resources[i].Weight == 0 {
+			obj.ScoringStrategy.Resources[i].Weight = 1
+		}
+	}
+
+	if obj.MetricsProvider == "" {
+		obj.MetricsProvider = "metrics-server"
+	}
+	if obj.Prometheus.NodeLabel == "" {
+		obj.Prometheus.NodeLabel = defaultNodeLabel
+	}
+	if obj.Prometheus.ScrapeIntervalSeconds == 0 {
+		obj.Prometheus.ScrapeIntervalSeconds = defaultScrapeIntervalSeconds
+	}
+	if obj.EWMAAlpha == 0 {
+		obj.EWMAAlpha = defaultEWMAAlpha
+	}
+	if obj.AssumedLoadTTLMultiplier == 0 {
+		obj.AssumedLoadTTLMultiplier = defaultAssumedLoadTTLMultiplier
+	}
+	if obj.QosLabelKey == "" {
+		obj.QosLabelKey = defaultQosLabelKey
+	}
+}
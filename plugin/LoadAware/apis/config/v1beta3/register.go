@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta3
+
+import (
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"Load-Aware-Scheduler-Plugin/plugin/LoadAware/apis/config"
+)
+
+// GroupName is the group name used in this API.
+const GroupName = "loadaware.config.k8s.io"
+
+// SchemeGroupVersion is group version used to register these objects.
+var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1beta3"}
+
+var (
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme.
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes, addDefaultingFuncs, addConversionFuncs)
+	// AddToScheme is a global function that registers this API group & version to a scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion, &LoadAwareArgs{})
+	return nil
+}
+
+// addConversionFuncs registers the hand-written v1beta3<->config.LoadAwareArgs converters
+// with scheme, so the scheduler's plugin-config decoder (which decodes into the external
+// v1beta3 type, then converts to the internal config type NewAllocatable consumes) actually
+// produces a *config.LoadAwareArgs instead of erroring with "converter not found".
+func addConversionFuncs(scheme *runtime.Scheme) error {
+	if err := scheme.AddConversionFunc((*LoadAwareArgs)(nil), (*config.LoadAwareArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta3_LoadAwareArgs_To_config_LoadAwareArgs(a.(*LoadAwareArgs), b.(*config.LoadAwareArgs))
+	}); err != nil {
+		return err
+	}
+	return scheme.AddConversionFunc((*config.LoadAwareArgs)(nil), (*LoadAwareArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_LoadAwareArgs_To_v1beta3_LoadAwareArgs(a.(*config.LoadAwareArgs), b.(*LoadAwareArgs))
+	})
+}
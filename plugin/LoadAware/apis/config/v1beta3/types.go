@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1beta3 is the v1beta3 external API for the NodeResourcesAllocatable plugin's
+// arguments, decoded straight off a KubeSchedulerConfiguration pluginConfig block.
+package v1beta3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScoringStrategyType is the type of a ScoringStrategy.
+type ScoringStrategyType string
+
+const (
+	LeastAllocated           ScoringStrategyType = "LeastAllocated"
+	MostAllocated            ScoringStrategyType = "MostAllocated"
+	BalancedAllocation       ScoringStrategyType = "BalancedAllocation"
+	RequestedToCapacityRatio ScoringStrategyType = "RequestedToCapacityRatio"
+)
+
+// LoadAwareArgs holds arguments used to configure the NodeResourcesAllocatable plugin.
+type LoadAwareArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// ScoringStrategy selects the resource scoring heuristic and its per-resource weights.
+	ScoringStrategy *ScoringStrategy `json:"scoringStrategy,omitempty"`
+
+	// MetricsProvider selects where per-node usage comes from: metrics-server (default),
+	// prometheus, or hybrid.
+	MetricsProvider string `json:"metricsProvider,omitempty"`
+	// Prometheus configures the optional Prometheus metrics source.
+	Prometheus PrometheusConfig `json:"prometheus,omitempty"`
+	// EWMAAlpha is the weight given to each new sample when smoothing across scrape
+	// intervals. Defaults to 0.5.
+	EWMAAlpha float64 `json:"ewmaAlpha,omitempty"`
+	// HotSpotThresholds maps a resource name to the smoothed utilization percentage at or
+	// above which a node is scored near-zero.
+	HotSpotThresholds map[string]int64 `json:"hotSpotThresholds,omitempty"`
+
+	// AssumedLoadTTLMultiplier is how many multiples of the metrics scrape interval a
+	// Reserve'd pod's request stays counted as assumed load. Defaults to 2.
+	AssumedLoadTTLMultiplier float64 `json:"assumedLoadTTLMultiplier,omitempty"`
+	// HardCaps maps a resource name to the projected-utilization percentage above which
+	// Filter rejects a node outright.
+	HardCaps map[string]int64 `json:"hardCaps,omitempty"`
+
+	// PodResourcesEndpoint is the kubelet pod-resources gRPC socket to poll for
+	// device-level allocation state.
+	PodResourcesEndpoint string `json:"podResourcesEndpoint,omitempty"`
+	// DeviceAware enables blending free-device-topology signal into the node score.
+	DeviceAware bool `json:"deviceAware,omitempty"`
+
+	// QosLabelKey is the pod label checked against QosResourceMappings. Defaults to
+	// "qos.example.io/level".
+	QosLabelKey string `json:"qosLabelKey,omitempty"`
+	// QosResourceMappings lets pods carrying a matching QosLabelKey value be scored
+	// against reclaimed-pool capacity instead of nominal node allocatable.
+	QosResourceMappings []QosResourceMapping `json:"qosResourceMappings,omitempty"`
+}
+
+// ScoringStrategy defines the scoring strategy for the plugin, mirroring
+// NodeResourcesFitArgs.ScoringStrategy from kube-scheduler's built-in NodeResourcesFit
+// plugin.
+type ScoringStrategy struct {
+	// Type selects which strategy to run. Defaults to LeastAllocated.
+	Type ScoringStrategyType `json:"type,omitempty"`
+	// Resources lists the resources to score and their relative weight. Defaults to cpu
+	// and memory with the plugin's built-in default weights.
+	Resources []ResourceSpec `json:"resources,omitempty"`
+	// RequestedToCapacityRatio configures the shape function used when Type is
+	// RequestedToCapacityRatio.
+	RequestedToCapacityRatio *RequestedToCapacityRatioParam `json:"requestedToCapacityRatio,omitempty"`
+}
+
+// ResourceSpec names a resource and how heavily it should count towards a node's score.
+type ResourceSpec struct {
+	// Name is the resource name, e.g. "cpu" or "memory".
+	Name string `json:"name"`
+	// Weight is the relative weight given to this resource. Must be positive.
+	Weight int64 `json:"weight,omitempty"`
+}
+
+// RequestedToCapacityRatioParam holds the utilization->score shape used by
+// the RequestedToCapacityRatio scoring strategy.
+type RequestedToCapacityRatioParam struct {
+	// Shape is the piecewise-linear utilization->score function shared by every resource
+	// scored under this strategy. Utilization must be strictly increasing across points.
+	Shape []UtilizationShapePoint `json:"shape,omitempty"`
+}
+
+// UtilizationShapePoint is one point of a piecewise-linear utilization->score function.
+type UtilizationShapePoint struct {
+	// Utilization is a percentage of allocatable, in [0, 100].
+	Utilization int32 `json:"utilization"`
+	// Score is the score assigned at this utilization, in [0, 10].
+	Score int32 `json:"score"`
+}
+
+// PrometheusConfig configures the optional Prometheus metrics source.
+type PrometheusConfig struct {
+	// Address is the base URL of the Prometheus server.
+	Address string `json:"address,omitempty"`
+	// NodeLabel is the label on the PromQL result that carries the node name. Defaults to
+	// "node".
+	NodeLabel string `json:"nodeLabel,omitempty"`
+	// Queries maps a resource name to the PromQL expression used to sample its usage.
+	Queries map[string]string `json:"queries,omitempty"`
+	// ScrapeIntervalSeconds is how often the configured queries are re-evaluated. Defaults
+	// to 30.
+	ScrapeIntervalSeconds int64 `json:"scrapeIntervalSeconds,omitempty"`
+}
+
+// QosResourceMapping associates a pod QoS label value with the scalar ("reclaimed")
+// resources that represent spare capacity left over by guaranteed workloads.
+type QosResourceMapping struct {
+	// QosLabel is the pod label value that selects this mapping.
+	QosLabel string `json:"qosLabel"`
+	// CPUResource is the scalar resource name reporting reclaimable millicores.
+	CPUResource string `json:"cpuResource,omitempty"`
+	// MemResource is the scalar resource name reporting reclaimable memory bytes.
+	MemResource string `json:"memResource,omitempty"`
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validation validates the internal config.LoadAwareArgs type, mirroring
+// k8s.io/kubernetes/pkg/scheduler/apis/config/validation's split from the types package.
+package validation
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"Load-Aware-Scheduler-Plugin/plugin/LoadAware/apis/config"
+)
+
+var supportedScoringStrategyTypes = map[config.ScoringStrategyType]bool{
+	config.LeastAllocated:           true,
+	config.MostAllocated:            true,
+	config.BalancedAllocation:       true,
+	config.RequestedToCapacityRatio: true,
+}
+
+// ValidateLoadAwareArgs validates args and returns an aggregate of every problem found, or
+// nil if args is valid.
+func ValidateLoadAwareArgs(args *config.LoadAwareArgs) error {
+	var errs field.ErrorList
+	path := field.NewPath("")
+
+	if args.ScoringStrategy != nil {
+		errs = append(errs, validateScoringStrategy(args.ScoringStrategy, path.Child("scoringStrategy"))...)
+	}
+	if args.EWMAAlpha < 0 || args.EWMAAlpha > 1 {
+		errs = append(errs, field.Invalid(path.Child("ewmaAlpha"), args.EWMAAlpha, "must be in [0, 1]"))
+	}
+	for resource, threshold := range args.HotSpotThresholds {
+		if threshold < 0 || threshold > 100 {
+			errs = append(errs, field.Invalid(path.Child("hotSpotThresholds").Key(resource), threshold, "must be a percentage in [0, 100]"))
+		}
+	}
+	for resource, cap := range args.HardCaps {
+		if cap < 0 || cap > 100 {
+			errs = append(errs, field.Invalid(path.Child("hardCaps").Key(resource), cap, "must be a percentage in [0, 100]"))
+		}
+	}
+	if args.AssumedLoadTTLMultiplier < 0 {
+		errs = append(errs, field.Invalid(path.Child("assumedLoadTTLMultiplier"), args.AssumedLoadTTLMultiplier, "must not be negative"))
+	}
+	if args.DeviceAware && args.PodResourcesEndpoint != "" && !strings.Contains(args.PodResourcesEndpoint, "%s") {
+		errs = append(errs, field.Invalid(path.Child("podResourcesEndpoint"), args.PodResourcesEndpoint,
+			"must contain a %s placeholder for the node name so each node's own kubelet is dialed; a fixed endpoint would report one node's device state for every node"))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs.ToAggregate()
+}
+
+func validateScoringStrategy(strategy *config.ScoringStrategy, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if strategy.Type != "" && !supportedScoringStrategyTypes[strategy.Type] {
+		errs = append(errs, field.NotSupported(path.Child("type"), strategy.Type, supportedScoringStrategyTypeNames()))
+	}
+
+	seen := map[string]bool{}
+	for i, resource := range strategy.Resources {
+		resourcePath := path.Child("resources").Index(i)
+		if resource.Name == "" {
+			errs = append(errs, field.Required(resourcePath.Child("name"), "resource name must not be empty"))
+		} else if seen[resource.Name] {
+			errs = append(errs, field.Duplicate(resourcePath.Child("name"), resource.Name))
+		}
+		seen[resource.Name] = true
+		if resource.Weight < 0 {
+			errs = append(errs, field.Invalid(resourcePath.Child("weight"), resource.Weight, "must not be negative"))
+		}
+	}
+
+	if strategy.Type == config.RequestedToCapacityRatio {
+		if strategy.RequestedToCapacityRatio == nil || len(strategy.RequestedToCapacityRatio.Shape) == 0 {
+			errs = append(errs, field.Required(path.Child("requestedToCapacityRatio", "shape"), "RequestedToCapacityRatio requires at least one shape point"))
+		} else {
+			errs = append(errs, validateShape(strategy.RequestedToCapacityRatio.Shape, path.Child("requestedToCapacityRatio", "shape"))...)
+		}
+	}
+
+	return errs
+}
+
+// validateShape checks that utilization is in [0, 100], score is in [0, 10], and points are
+// sorted with strictly increasing utilization - a non-monotonic shape has no well-defined
+// interpolation.
+func validateShape(shape []config.UtilizationShapePoint, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, point := range shape {
+		pointPath := path.Index(i)
+		if point.Utilization < 0 || point.Utilization > 100 {
+			errs = append(errs, field.Invalid(pointPath.Child("utilization"), point.Utilization, "must be in [0, 100]"))
+		}
+		if point.Score < 0 || point.Score > 10 {
+			errs = append(errs, field.Invalid(pointPath.Child("score"), point.Score, "must be in [0, 10]"))
+		}
+		if i > 0 && point.Utilization <= shape[i-1].Utilization {
+			errs = append(errs, field.Invalid(pointPath.Child("utilization"), point.Utilization, "utilization values must be strictly increasing"))
+		}
+	}
+	return errs
+}
+
+func supportedScoringStrategyTypeNames() []string {
+	return []string{
+		string(config.LeastAllocated),
+		string(config.MostAllocated),
+		string(config.BalancedAllocation),
+		string(config.RequestedToCapacityRatio),
+	}
+}
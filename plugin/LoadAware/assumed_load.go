@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file implements a shared, in-memory cache of "assumed" load: resources that the
+// scheduler has committed to a node via Reserve but that metrics-server/Prometheus haven't
+// observed yet because they sample on an interval. Without it, a burst of pods scheduled
+// within one scrape interval all see the same stale usage and pile onto the same node.
+
+package LoadAware
+
+import (
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// defaultAssumedLoadTTLMultiplier is used when NewAllocatable is built with an unset (<= 0)
+// TTL multiplier. The TTL is this multiple of the scrape interval, since that's how long it
+// takes a real scrape to catch up with a just-scheduled pod.
+const defaultAssumedLoadTTLMultiplier = 2
+
+// assumedEntry is one pod's contribution to a node's assumed load. Its weight decays
+// linearly from 1 to 0 over [addedAt, addedAt+ttl), so that assumed load hands off to real
+// metrics smoothly as scrapes catch up instead of stepping down abruptly.
+type assumedEntry struct {
+	podUID  string
+	request resourceToValueMap
+	addedAt time.Time
+}
+
+// assumedLoadCache tracks, per node, the requests of recently-reserved pods that metrics
+// haven't caught up with yet. It is safe for concurrent use.
+type assumedLoadCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string][]assumedEntry
+}
+
+// newAssumedLoadCache returns a cache whose entries decay to zero weight after ttl.
+func newAssumedLoadCache(ttl time.Duration) *assumedLoadCache {
+	return &assumedLoadCache{
+		ttl:     ttl,
+		entries: make(map[string][]assumedEntry),
+	}
+}
+
+// add records podUID's request against nodeName, starting its decay clock now.
+func (c *assumedLoadCache) add(nodeName, podUID string, request resourceToValueMap, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[nodeName] = append(c.entries[nodeName], assumedEntry{
+		podUID:  podUID,
+		request: request,
+		addedAt: now,
+	})
+}
+
+// remove drops podUID's entry for nodeName, e.g. once the real binding fails and is undone.
+func (c *assumedLoadCache) remove(nodeName, podUID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entries := c.entries[nodeName]
+	for i, e := range entries {
+		if e.podUID == podUID {
+			c.entries[nodeName] = append(entries[:i], entries[i+1:]...)
+			return
+		}
+	}
+}
+
+// value returns nodeName's current assumed load per resource: the sum of each live entry's
+// request, weighted by how much of its TTL remains. Expired entries are pruned as a side
+// effect so the cache doesn't grow without bound.
+func (c *assumedLoadCache) value(nodeName string, now time.Time) resourceToValueMap {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries := c.entries[nodeName]
+	if len(entries) == 0 {
+		return nil
+	}
+
+	live := entries[:0]
+	total := make(resourceToValueMap)
+	for _, e := range entries {
+		age := now.Sub(e.addedAt)
+		if age >= c.ttl {
+			continue
+		}
+		live = append(live, e)
+
+		weight := 1 - float64(age)/float64(c.ttl)
+		for resource, amount := range e.request {
+			total[resource] += int64(float64(amount) * weight)
+		}
+	}
+	c.entries[nodeName] = live
+
+	return total
+}
+
+// podResourceRequests sums a pod's container requests into a resourceToValueMap covering
+// cpu (millicores) and memory (bytes), the two resources Reserve/Unreserve track.
+func podResourceRequests(pod *v1.Pod) resourceToValueMap {
+	var milliCPU, memory int64
+	for _, c := range pod.Spec.Containers {
+		milliCPU += c.Resources.Requests.Cpu().MilliValue()
+		memory += c.Resources.Requests.Memory().Value()
+	}
+	return resourceToValueMap{
+		v1.ResourceCPU:    milliCPU,
+		v1.ResourceMemory: memory,
+	}
+}
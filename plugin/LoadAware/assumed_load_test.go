@@ -0,0 +1,90 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package LoadAware
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// TestAssumedLoadCacheSpreadsBurst schedules 20 identical pods one at a time against two
+// equally-sized nodes, each time picking whichever node the cache currently reports as
+// least loaded, as score() would when metrics haven't caught up yet. Without the assumed
+// load cache every pod would see identical (zero) usage from both nodes and stampede onto
+// one of them; with it, load should spread close to evenly.
+func TestAssumedLoadCacheSpreadsBurst(t *testing.T) {
+	cache := newAssumedLoadCache(time.Minute)
+	now := time.Now()
+
+	nodes := []string{"node-a", "node-b"}
+	podRequest := resourceToValueMap{v1.ResourceCPU: 1000}
+	picks := make(map[string]int)
+
+	for i := 0; i < 20; i++ {
+		var best string
+		var bestLoad int64 = -1
+		for _, node := range nodes {
+			load := cache.value(node, now)[v1.ResourceCPU]
+			if bestLoad == -1 || load < bestLoad {
+				best, bestLoad = node, load
+			}
+		}
+		picks[best]++
+		cache.add(best, fmt.Sprintf("pod-%d", i), podRequest, now)
+	}
+
+	for _, node := range nodes {
+		if picks[node] != 10 {
+			t.Errorf("expected burst to split evenly across nodes, got %v", picks)
+			break
+		}
+	}
+}
+
+// TestAssumedLoadCacheDecaysAndExpires verifies that an entry's contribution shrinks over
+// its TTL and disappears once the TTL has fully elapsed.
+func TestAssumedLoadCacheDecaysAndExpires(t *testing.T) {
+	cache := newAssumedLoadCache(10 * time.Second)
+	start := time.Now()
+	cache.add("node-a", "pod-0", resourceToValueMap{v1.ResourceCPU: 1000}, start)
+
+	if got := cache.value("node-a", start)[v1.ResourceCPU]; got != 1000 {
+		t.Errorf("expected full weight immediately after add, got %d", got)
+	}
+	if got := cache.value("node-a", start.Add(5*time.Second))[v1.ResourceCPU]; got != 500 {
+		t.Errorf("expected half weight at half the TTL, got %d", got)
+	}
+	if got := cache.value("node-a", start.Add(11*time.Second))[v1.ResourceCPU]; got != 0 {
+		t.Errorf("expected entry to have expired past its TTL, got %d", got)
+	}
+}
+
+// TestAssumedLoadCacheRemove verifies Unreserve's effect: a removed entry no longer
+// contributes, even before its TTL would otherwise have expired it.
+func TestAssumedLoadCacheRemove(t *testing.T) {
+	cache := newAssumedLoadCache(time.Minute)
+	now := time.Now()
+	cache.add("node-a", "pod-0", resourceToValueMap{v1.ResourceCPU: 1000}, now)
+	cache.remove("node-a", "pod-0")
+
+	if got := cache.value("node-a", now)[v1.ResourceCPU]; got != 0 {
+		t.Errorf("expected removed entry to contribute nothing, got %d", got)
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package LoadAware
+
+import (
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// ewmaCache smooths per-node, per-resource samples across scrape intervals so a single
+// noisy or stale sample doesn't swing a node's score. It is safe for concurrent use.
+type ewmaCache struct {
+	alpha float64
+
+	mu   sync.Mutex
+	prev map[string]map[v1.ResourceName]int64
+}
+
+// newEWMACache returns a cache that smooths with the given alpha, the weight given to each
+// new sample: ewma_new = alpha*sample + (1-alpha)*ewma_prev.
+func newEWMACache(alpha float64) *ewmaCache {
+	if alpha <= 0 || alpha > 1 {
+		alpha = defaultEWMAAlpha
+	}
+	return &ewmaCache{
+		alpha: alpha,
+		prev:  make(map[string]map[v1.ResourceName]int64),
+	}
+}
+
+// update folds sample into the node/resource EWMA and returns the new smoothed value. The
+// first sample observed for a node/resource pair seeds the EWMA rather than being smoothed.
+func (e *ewmaCache) update(nodeName string, resource v1.ResourceName, sample int64) int64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	byResource, ok := e.prev[nodeName]
+	if !ok {
+		byResource = make(map[v1.ResourceName]int64)
+		e.prev[nodeName] = byResource
+	}
+
+	prev, seen := byResource[resource]
+	if !seen {
+		byResource[resource] = sample
+		return sample
+	}
+
+	smoothed := int64(e.alpha*float64(sample) + (1-e.alpha)*float64(prev))
+	byResource[resource] = smoothed
+	return smoothed
+}
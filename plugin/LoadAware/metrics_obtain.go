@@ -22,6 +22,8 @@ package LoadAware
 import (
 	"context"
 	"encoding/json"
+	"sync"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,20 +42,102 @@ type resourceToWeightMap map[v1.ResourceName]int64
 // has a weighted score equivalent to 1 MiB.
 var defaultResourcesToWeightMap = resourceToWeightMap{v1.ResourceMemory: 1, v1.ResourceCPU: 1 << 20, v1.ResourcePodNum: 1}
 
+// metricsProvider selects where score() sources per-node resource usage from.
+type metricsProvider string
+
+const (
+	// MetricsProviderMetricsServer reads instantaneous usage from metrics-server, as before.
+	MetricsProviderMetricsServer metricsProvider = "metrics-server"
+	// MetricsProviderPrometheus reads cached, EWMA-smoothed samples populated by PromQL scrapes.
+	MetricsProviderPrometheus metricsProvider = "prometheus"
+	// MetricsProviderHybrid prefers the Prometheus cache but falls back to metrics-server
+	// for nodes/resources the cache hasn't populated yet.
+	MetricsProviderHybrid metricsProvider = "hybrid"
+)
+
+// defaultEWMAAlpha is the fallback used by newEWMACache if constructed with a zero alpha;
+// LoadAwareArgs.EWMAAlpha is normally already defaulted by v1beta3.SetDefaults_LoadAwareArgs.
+const defaultEWMAAlpha = 0.5
+
+// defaultHotSpotThreshold is the utilization percentage (of allocatable) at or above which a
+// node is treated as a hot spot for a resource that has no threshold of its own while other
+// resources do. With no thresholds configured at all, the hot-spot check is off.
+const defaultHotSpotThreshold = 80
+
 // resourceAllocationScorer contains information to calculate resource allocation score.
 type resourceAllocationScorer struct {
 	Name                string
-	scorer              func(requested, allocatable resourceToValueMap) int64
+	scorer              func(requested, allocatable, smoothed resourceToValueMap) int64
 	resourceToWeightMap resourceToWeightMap
 	metricsClient       *metricsclient.Clientset
+
+	// metricsProvider selects the usage data source; empty defaults to metrics-server.
+	metricsProvider metricsProvider
+	// promClient serves cached PromQL samples when metricsProvider is prometheus or hybrid.
+	promClient *prometheusClient
+	// ewma smooths samples across scrape intervals before they reach the scorer.
+	ewma *ewmaCache
+	// hotSpotThresholds is the per-resource utilization percentage (of allocatable) at or
+	// above which score() short-circuits to a near-zero score instead of calling scorer.
+	hotSpotThresholds map[v1.ResourceName]int64
+
+	// assumedLoad tracks recently-reserved-but-not-yet-observed pod requests per node, so a
+	// burst of pods scheduled within one scrape interval doesn't all pile onto the same
+	// node. Populated by Allocatable.Reserve/Unreserve.
+	assumedLoad *assumedLoadCache
+	// hardCaps is the per-resource projected-utilization percentage (of allocatable) above
+	// which Allocatable.Filter rejects a node outright.
+	hardCaps map[v1.ResourceName]int64
+
+	// deviceAware enables blending a contiguous-free-device bonus into the node score,
+	// sourced from podResourcesClient instead of metrics-server/Prometheus.
+	deviceAware        bool
+	podResourcesClient *podResourcesClient
+	trackedDeviceNodes sync.Map
+
+	// qosLabelKey is the pod label key checked against qosResourceMappings to decide
+	// whether a pod should be scored against reclaimed-pool capacity instead of nominal
+	// node allocatable.
+	qosLabelKey         string
+	qosResourceMappings []QosResourceMapping
+}
+
+// QosResourceMapping associates a pod QoS label value with the scalar ("reclaimed")
+// resources that represent spare capacity left over by guaranteed workloads, analogous to
+// katalyst's reclaimed_millicpu/reclaimed_memory extended resources.
+type QosResourceMapping struct {
+	// QosLabel is the pod label value (under LoadAwareArgs.QosLabelKey) that selects this mapping.
+	QosLabel string `json:"qosLabel"`
+	// CPUResource is the scalar resource name reporting reclaimable millicores.
+	CPUResource v1.ResourceName `json:"cpuResource,omitempty"`
+	// MemResource is the scalar resource name reporting reclaimable memory bytes.
+	MemResource v1.ResourceName `json:"memResource,omitempty"`
+}
+
+// reclaimedMappingFor returns the QosResourceMapping that applies to pod, if any of
+// qosResourceMappings matches its qosLabelKey label.
+func (r *resourceAllocationScorer) reclaimedMappingFor(pod *v1.Pod) (QosResourceMapping, bool) {
+	if pod == nil || len(r.qosResourceMappings) == 0 {
+		return QosResourceMapping{}, false
+	}
+	label := pod.Labels[r.qosLabelKey]
+	for _, mapping := range r.qosResourceMappings {
+		if mapping.QosLabel == label {
+			return mapping, true
+		}
+	}
+	return QosResourceMapping{}, false
 }
 
 // resourceToValueMap contains resource name and score.
 type resourceToValueMap map[v1.ResourceName]int64
 
+// nearZeroScore is returned for nodes whose smoothed utilization crosses a hot-spot threshold.
+const nearZeroScore = 1
+
 // score will use `scorer` function to calculate the score.
 func (r *resourceAllocationScorer) score(
-	nodeInfo *framework.NodeInfo) (int64, *framework.Status) {
+	pod *v1.Pod, nodeInfo *framework.NodeInfo) (int64, *framework.Status) {
 	node := nodeInfo.Node()
 	if node == nil {
 		return 0, framework.NewStatus(framework.Error, "node not found")
@@ -61,12 +145,24 @@ func (r *resourceAllocationScorer) score(
 	if r.resourceToWeightMap == nil {
 		return 0, framework.NewStatus(framework.Error, "resources not found")
 	}
+	reclaimed, usesReclaimed := r.reclaimedMappingFor(pod)
 	cost := make(resourceToValueMap, len(r.resourceToWeightMap))
 	allocatable := make(resourceToValueMap, len(r.resourceToWeightMap))
+	smoothed := make(resourceToValueMap, len(r.resourceToWeightMap))
+	assumed := r.assumedLoadFor(node.Name)
 	for resource := range r.resourceToWeightMap {
-		allocatable[resource], cost[resource] = r.calculateResourceAllocatableCost(nodeInfo, resource)
+		allocatable[resource], cost[resource] = r.calculateResourceAllocatableCost(nodeInfo, resource, reclaimed, usesReclaimed)
+		cost[resource] += assumed[resource]
+		smoothed[resource] = r.smooth(node.Name, resource, cost[resource])
+		if r.isHotSpot(resource, smoothed[resource], allocatable[resource]) {
+			klog.InfoS("Node crossed hot-spot threshold, applying near-zero score", "node", node.Name, "resource", resource)
+			return nearZeroScore, nil
+		}
+	}
+	score := r.scorer(cost, allocatable, smoothed)
+	if r.deviceAware && r.podResourcesClient != nil {
+		score = r.applyDeviceBonus(node.Name, score)
 	}
-	score := r.scorer(cost, allocatable)
 
 	allocatableJson, _ := json.Marshal(allocatable)
 	costJson, _ := json.Marshal(cost)
@@ -75,8 +171,128 @@ func (r *resourceAllocationScorer) score(
 	return score, nil
 }
 
-// calculateResourceAllocatableRequest returns resources Allocatable and Requested values
-func (r *resourceAllocationScorer) calculateResourceAllocatableCost(nodeInfo *framework.NodeInfo, resource v1.ResourceName) (int64, int64) {
+// applyDeviceBonus blends a contiguous-free-device signal into score, for every scalar
+// resource the plugin is configured to weigh that also has cached pod-resources device
+// state. Nodes with more, and more contiguous, free devices score higher, so that
+// NUMA/device-topology-aware spreading doesn't have to wait on a metrics averaging window.
+func (r *resourceAllocationScorer) applyDeviceBonus(nodeName string, score int64) int64 {
+	r.ensureDeviceTracking(nodeName)
+
+	var weightedBonus, totalWeight int64
+	for resource, weight := range r.resourceToWeightMap {
+		state, ok := r.podResourcesClient.deviceState(nodeName, resource)
+		if !ok || len(state.allocatableIDs) == 0 {
+			continue
+		}
+		bonus := int64(state.freeContiguousRun()) * int64(framework.MaxNodeScore) / int64(len(state.allocatableIDs))
+		weightedBonus += bonus * weight
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		return score
+	}
+	return (score + weightedBonus/totalWeight) / 2
+}
+
+// ensureDeviceTracking lazily starts a poll loop for nodeName the first time it's scored,
+// so the plugin doesn't have to be told the node set up front.
+func (r *resourceAllocationScorer) ensureDeviceTracking(nodeName string) {
+	if _, already := r.trackedDeviceNodes.LoadOrStore(nodeName, true); already {
+		return
+	}
+	go r.podResourcesClient.run(context.Background(), nodeName)
+}
+
+// assumedLoadFor returns the decayed, recently-reserved load for a node, or nil if no
+// assumed-load cache is configured.
+func (r *resourceAllocationScorer) assumedLoadFor(nodeName string) resourceToValueMap {
+	if r.assumedLoad == nil {
+		return nil
+	}
+	return r.assumedLoad.value(nodeName, time.Now())
+}
+
+// smooth folds sample into the node/resource EWMA and returns the updated value. If no EWMA
+// cache is configured (the common metrics-server-only path), the raw sample passes through.
+func (r *resourceAllocationScorer) smooth(nodeName string, resource v1.ResourceName, sample int64) int64 {
+	if r.ewma == nil {
+		return sample
+	}
+	return r.ewma.update(nodeName, resource, sample)
+}
+
+// isHotSpot reports whether the smoothed utilization of resource on a node crosses the
+// configured threshold, expressed as a percentage of allocatable.
+func (r *resourceAllocationScorer) isHotSpot(resource v1.ResourceName, smoothed, allocatable int64) bool {
+	if allocatable == 0 || r.hotSpotThresholds == nil {
+		return false
+	}
+	threshold, ok := r.hotSpotThresholds[resource]
+	if !ok {
+		threshold = defaultHotSpotThreshold
+	}
+	return smoothed*100/allocatable >= threshold
+}
+
+// effectiveUsage returns the value the scorer should treat as "used" for resource: the
+// EWMA-smoothed sample when one is available, otherwise the raw requested/observed value.
+func effectiveUsage(requested, smoothed resourceToValueMap, resource v1.ResourceName) int64 {
+	if v, ok := smoothed[resource]; ok && v > 0 {
+		return v
+	}
+	return requested[resource]
+}
+
+// calculateResourceAllocatableCost returns resource's (allocatable, used) pair for
+// nodeInfo. When usesReclaimed is true, both allocatable and used are taken from the
+// reclaimed-pool scalar resource named in reclaimed instead of the node's nominal cpu/memory
+// figures, so best-effort pods carrying a matching QoS label are scored against the capacity
+// (and usage) left over by guaranteed workloads rather than the node's total cpu/memory
+// usage, which would almost always exceed the much smaller reclaimed pool.
+func (r *resourceAllocationScorer) calculateResourceAllocatableCost(nodeInfo *framework.NodeInfo, resource v1.ResourceName, reclaimed QosResourceMapping, usesReclaimed bool) (int64, int64) {
+	allocatable, used := r.costFromMetrics(nodeInfo, resource)
+	if usesReclaimed {
+		if reclaimedResource, ok := reclaimedResourceName(reclaimed, resource); ok {
+			if reclaimedAllocatable, ok := nodeInfo.Allocatable.ScalarResources[reclaimedResource]; ok {
+				allocatable = reclaimedAllocatable
+				used = nodeInfo.Requested.ScalarResources[reclaimedResource]
+			}
+		}
+	}
+	return allocatable, used
+}
+
+// reclaimedResourceName returns the scalar resource name that backs resource's reclaimed
+// pool under mapping, if resource is cpu or memory (the only resources katalyst-style
+// reclaimed pools cover).
+func reclaimedResourceName(mapping QosResourceMapping, resource v1.ResourceName) (v1.ResourceName, bool) {
+	switch resource {
+	case v1.ResourceCPU:
+		return mapping.CPUResource, mapping.CPUResource != ""
+	case v1.ResourceMemory:
+		return mapping.MemResource, mapping.MemResource != ""
+	default:
+		return "", false
+	}
+}
+
+// costFromMetrics returns resources Allocatable and Requested values
+func (r *resourceAllocationScorer) costFromMetrics(nodeInfo *framework.NodeInfo, resource v1.ResourceName) (int64, int64) {
+	allocatable, _ := calculateResourceAllocatableRequest(nodeInfo, resource)
+
+	switch r.metricsProvider {
+	case MetricsProviderPrometheus:
+		if value, ok := r.promValue(nodeInfo.Node().Name, resource); ok {
+			return allocatable, value
+		}
+		_, requested := calculateResourceAllocatableRequest(nodeInfo, resource)
+		return allocatable, requested
+	case MetricsProviderHybrid:
+		if value, ok := r.promValue(nodeInfo.Node().Name, resource); ok {
+			return allocatable, value
+		}
+	}
+
 	if r.metricsClient == nil {
 		return calculateResourceAllocatableRequest(nodeInfo, resource)
 	}
@@ -114,6 +330,15 @@ func (r *resourceAllocationScorer) calculateResourceAllocatableCost(nodeInfo *fr
 	return 0, 0
 }
 
+// promValue returns the cached, EWMA-ready sample for node/resource from the Prometheus
+// scrape cache, if the cache has been populated yet.
+func (r *resourceAllocationScorer) promValue(nodeName string, resource v1.ResourceName) (int64, bool) {
+	if r.promClient == nil {
+		return 0, false
+	}
+	return r.promClient.get(nodeName, resource)
+}
+
 func calculateResourceAllocatableRequest(nodeInfo *framework.NodeInfo, resource v1.ResourceName) (int64, int64) {
 	switch resource {
 	case v1.ResourceCPU:
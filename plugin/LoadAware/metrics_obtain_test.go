@@ -0,0 +1,153 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package LoadAware
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+// TestIsHotSpot covers isHotSpot's threshold-crossing logic: no thresholds configured,
+// below/at/above a configured per-resource threshold, falling back to the default threshold
+// for resources with no explicit entry, and the zero-allocatable edge case.
+func TestIsHotSpot(t *testing.T) {
+	tests := []struct {
+		name        string
+		thresholds  map[v1.ResourceName]int64
+		resource    v1.ResourceName
+		smoothed    int64
+		allocatable int64
+		want        bool
+	}{
+		{
+			name:        "no thresholds configured never trips",
+			thresholds:  nil,
+			resource:    v1.ResourceCPU,
+			smoothed:    1000,
+			allocatable: 1000,
+			want:        false,
+		},
+		{
+			name:        "below configured threshold",
+			thresholds:  map[v1.ResourceName]int64{v1.ResourceCPU: 80},
+			resource:    v1.ResourceCPU,
+			smoothed:    700,
+			allocatable: 1000,
+			want:        false,
+		},
+		{
+			name:        "exactly at configured threshold trips",
+			thresholds:  map[v1.ResourceName]int64{v1.ResourceCPU: 80},
+			resource:    v1.ResourceCPU,
+			smoothed:    800,
+			allocatable: 1000,
+			want:        true,
+		},
+		{
+			name:        "above configured threshold trips",
+			thresholds:  map[v1.ResourceName]int64{v1.ResourceCPU: 80},
+			resource:    v1.ResourceCPU,
+			smoothed:    900,
+			allocatable: 1000,
+			want:        true,
+		},
+		{
+			name:        "resource with no explicit entry falls back to the default threshold",
+			thresholds:  map[v1.ResourceName]int64{v1.ResourceMemory: 50},
+			resource:    v1.ResourceCPU,
+			smoothed:    850,
+			allocatable: 1000,
+			want:        true,
+		},
+		{
+			name:        "zero allocatable never trips to avoid a divide-by-zero false positive",
+			thresholds:  map[v1.ResourceName]int64{v1.ResourceCPU: 80},
+			resource:    v1.ResourceCPU,
+			smoothed:    100,
+			allocatable: 0,
+			want:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &resourceAllocationScorer{hotSpotThresholds: tt.thresholds}
+			if got := r.isHotSpot(tt.resource, tt.smoothed, tt.allocatable); got != tt.want {
+				t.Errorf("isHotSpot(%s, %d, %d) = %v, want %v", tt.resource, tt.smoothed, tt.allocatable, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCalculateResourceAllocatableCostReclaimed verifies that a pod matching a
+// QosResourceMapping is scored against the reclaimed pool's own (allocatable, used) pair,
+// not the node's total cpu/memory usage - which, on a node whose guaranteed pods already
+// consume most of the node, would exceed even a tiny reclaimed pool and drive every score to
+// zero. A pod that doesn't match any mapping must still see the node's ordinary allocatable
+// and requested cpu/memory, unaffected by the reclaimed pool's existence.
+func TestCalculateResourceAllocatableCostReclaimed(t *testing.T) {
+	mapping := QosResourceMapping{
+		QosLabel:    "reclaimed",
+		CPUResource: "reclaimed_millicpu",
+		MemResource: "reclaimed_memory",
+	}
+	r := &resourceAllocationScorer{
+		qosLabelKey:         "qos.example.io/level",
+		qosResourceMappings: []QosResourceMapping{mapping},
+	}
+
+	nodeInfo := framework.NewNodeInfo()
+	nodeInfo.SetNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}})
+	nodeInfo.Allocatable = &framework.Resource{
+		MilliCPU: 8000,
+		Memory:   8 << 30,
+		ScalarResources: map[v1.ResourceName]int64{
+			"reclaimed_millicpu": 2000,
+			"reclaimed_memory":   2 << 30,
+		},
+	}
+	nodeInfo.NonZeroRequested = &framework.Resource{MilliCPU: 6000, Memory: 6 << 30}
+	nodeInfo.Requested = &framework.Resource{
+		MilliCPU: 6000,
+		Memory:   6 << 30,
+		ScalarResources: map[v1.ResourceName]int64{
+			"reclaimed_millicpu": 500,
+			"reclaimed_memory":   1 << 30,
+		},
+	}
+
+	reclaimedPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"qos.example.io/level": "reclaimed"}}}
+	reclaimed, usesReclaimed := r.reclaimedMappingFor(reclaimedPod)
+	if !usesReclaimed {
+		t.Fatalf("expected pod labeled %q to match the reclaimed QoS mapping", "reclaimed")
+	}
+	if allocatable, used := r.calculateResourceAllocatableCost(nodeInfo, v1.ResourceCPU, reclaimed, usesReclaimed); allocatable != 2000 || used != 500 {
+		t.Errorf("reclaimed pod cpu (allocatable, used) = (%d, %d), want (2000, 500) - the reclaimed pool's own capacity and usage, not the node's total 8000/6000", allocatable, used)
+	}
+
+	regularPod := &v1.Pod{}
+	reclaimed, usesReclaimed = r.reclaimedMappingFor(regularPod)
+	if usesReclaimed {
+		t.Fatalf("expected unlabeled pod not to match any reclaimed QoS mapping")
+	}
+	if allocatable, used := r.calculateResourceAllocatableCost(nodeInfo, v1.ResourceCPU, reclaimed, usesReclaimed); allocatable != 8000 || used != 6000 {
+		t.Errorf("regular pod cpu (allocatable, used) = (%d, %d), want the node's ordinary (8000, 6000), unaffected by the reclaimed pool", allocatable, used)
+	}
+}
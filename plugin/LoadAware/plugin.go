@@ -0,0 +1,235 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package LoadAware
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
+
+	"Load-Aware-Scheduler-Plugin/plugin/LoadAware/apis/config"
+	"Load-Aware-Scheduler-Plugin/plugin/LoadAware/apis/config/v1beta3"
+	"Load-Aware-Scheduler-Plugin/plugin/LoadAware/apis/config/validation"
+)
+
+// AllocatableName is the name of the plugin used in the plugin registry and configurations.
+const AllocatableName = "NodeResourcesAllocatable"
+
+// Allocatable is a score plugin that favors nodes based on their allocatable resources.
+type Allocatable struct {
+	handle framework.Handle
+	resourceAllocationScorer
+}
+
+var _ framework.ScorePlugin = &Allocatable{}
+
+// Name returns name of the plugin.
+func (alloc *Allocatable) Name() string {
+	return AllocatableName
+}
+
+// Score invokes the resourceAllocationScorer to produce a node score.
+func (alloc *Allocatable) Score(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) (int64, *framework.Status) {
+	nodeInfo, err := alloc.handle.SnapshotSharedLister().NodeInfos().Get(nodeName)
+	if err != nil {
+		return 0, framework.NewStatus(framework.Error, fmt.Sprintf("getting node %q from Snapshot: %v", nodeName, err))
+	}
+	return alloc.score(pod, nodeInfo)
+}
+
+// ScoreExtensions returns nil as this plugin does not need a post-processing normalize step.
+func (alloc *Allocatable) ScoreExtensions() framework.ScoreExtensions {
+	return nil
+}
+
+// NewAllocatable initializes a new plugin and returns it. obj is the internal
+// config.LoadAwareArgs the scheduler's plugin-config decoder produces for this plugin's
+// pluginConfig block (see apis/config/install.go), or nil when no block was supplied.
+func NewAllocatable(obj runtime.Object, h framework.Handle) (framework.Plugin, error) {
+	args, err := decodeArgs(obj)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.ValidateLoadAwareArgs(args); err != nil {
+		return nil, fmt.Errorf("validating args for %s: %v", AllocatableName, err)
+	}
+
+	if args.ScoringStrategy == nil {
+		args.ScoringStrategy = &config.ScoringStrategy{Type: config.LeastAllocated}
+	}
+	strategy := args.ScoringStrategy
+	resToWeightMap := make(resourceToWeightMap, len(strategy.Resources))
+	for _, resource := range strategy.Resources {
+		resToWeightMap[v1.ResourceName(resource.Name)] = resource.Weight
+	}
+	qosResourceMappings := toLocalQosResourceMappings(args.QosResourceMappings)
+
+	var scorer func(requested, allocatable, smoothed resourceToValueMap) int64
+	switch strategy.Type {
+	case config.MostAllocated:
+		scorer = mostResourceScorer(resToWeightMap)
+	case config.BalancedAllocation:
+		scorer = balancedResourceScorer(resToWeightMap)
+	case config.RequestedToCapacityRatio:
+		points := toLocalShapePoints(strategy.RequestedToCapacityRatio.Shape)
+		shapes := make(map[v1.ResourceName][]UtilizationShapePoint, len(resToWeightMap))
+		for resource := range resToWeightMap {
+			shapes[resource] = points
+		}
+		scorer = requestedToCapacityRatioScorer(resToWeightMap, shapes)
+	case config.LeastAllocated, "":
+		scorer = leastResourceScorer(resToWeightMap)
+	default:
+		return nil, fmt.Errorf("unsupported scoring strategy type %q for %s", strategy.Type, AllocatableName)
+	}
+
+	var metricsCli *metricsclient.Clientset
+	if cfg, err := rest.InClusterConfig(); err == nil {
+		metricsCli, _ = metricsclient.NewForConfig(cfg)
+	}
+
+	provider := metricsProvider(args.MetricsProvider)
+	if provider == "" {
+		provider = MetricsProviderMetricsServer
+	}
+
+	scrapeInterval := time.Duration(args.Prometheus.ScrapeIntervalSeconds) * time.Second
+	if scrapeInterval <= 0 {
+		scrapeInterval = defaultPrometheusScrapeInterval
+	}
+
+	var promCli *prometheusClient
+	if provider == MetricsProviderPrometheus || provider == MetricsProviderHybrid {
+		var err error
+		promCli, err = newPrometheusClient(args.Prometheus.Address, args.Prometheus.NodeLabel, toLocalResourceNameMap(args.Prometheus.Queries), scrapeInterval)
+		if err != nil {
+			return nil, fmt.Errorf("building prometheus client for %s: %v", AllocatableName, err)
+		}
+		go promCli.run(context.Background())
+	}
+
+	ttlMultiplier := args.AssumedLoadTTLMultiplier
+	if ttlMultiplier <= 0 {
+		ttlMultiplier = defaultAssumedLoadTTLMultiplier
+	}
+
+	var podResourcesCli *podResourcesClient
+	if args.DeviceAware && args.PodResourcesEndpoint != "" {
+		podResourcesCli = newPodResourcesClient(staticPodResourcesEndpoint(args.PodResourcesEndpoint), 0)
+	}
+
+	return &Allocatable{
+		handle: h,
+		resourceAllocationScorer: resourceAllocationScorer{
+			Name:                AllocatableName,
+			scorer:              scorer,
+			resourceToWeightMap: resToWeightMap,
+			metricsClient:       metricsCli,
+			metricsProvider:     provider,
+			promClient:          promCli,
+			ewma:                newEWMACache(args.EWMAAlpha),
+			hotSpotThresholds:   toLocalResourceValueMap(args.HotSpotThresholds),
+			assumedLoad:         newAssumedLoadCache(time.Duration(float64(scrapeInterval) * ttlMultiplier)),
+			hardCaps:            toLocalResourceValueMap(args.HardCaps),
+			deviceAware:         args.DeviceAware,
+			podResourcesClient:  podResourcesCli,
+			qosLabelKey:         args.QosLabelKey,
+			qosResourceMappings: qosResourceMappings,
+		},
+	}, nil
+}
+
+// decodeArgs returns the internal args to build the plugin from, defaulting a nil obj the
+// same way an empty pluginConfig block is defaulted.
+func decodeArgs(obj runtime.Object) (*config.LoadAwareArgs, error) {
+	if obj == nil {
+		external := &v1beta3.LoadAwareArgs{}
+		v1beta3.SetDefaults_LoadAwareArgs(external)
+		args := &config.LoadAwareArgs{}
+		if err := v1beta3.Convert_v1beta3_LoadAwareArgs_To_config_LoadAwareArgs(external, args); err != nil {
+			return nil, err
+		}
+		return args, nil
+	}
+	args, ok := obj.(*config.LoadAwareArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type LoadAwareArgs, got %T", obj)
+	}
+	return args, nil
+}
+
+// toLocalQosResourceMappings converts the typed config.QosResourceMapping (string resource
+// names, as decoded off the wire) into this package's v1.ResourceName-keyed equivalent.
+func toLocalQosResourceMappings(in []config.QosResourceMapping) []QosResourceMapping {
+	if in == nil {
+		return nil
+	}
+	out := make([]QosResourceMapping, 0, len(in))
+	for _, m := range in {
+		out = append(out, QosResourceMapping{
+			QosLabel:    m.QosLabel,
+			CPUResource: v1.ResourceName(m.CPUResource),
+			MemResource: v1.ResourceName(m.MemResource),
+		})
+	}
+	return out
+}
+
+// toLocalShapePoints converts config.UtilizationShapePoint (int32 fields, as decoded off the
+// wire) into this package's int64-scored equivalent.
+func toLocalShapePoints(in []config.UtilizationShapePoint) []UtilizationShapePoint {
+	if in == nil {
+		return nil
+	}
+	out := make([]UtilizationShapePoint, 0, len(in))
+	for _, p := range in {
+		out = append(out, UtilizationShapePoint{Utilization: int64(p.Utilization), Score: int64(p.Score)})
+	}
+	return out
+}
+
+// toLocalResourceValueMap converts a string-keyed percentage map into a v1.ResourceName-keyed
+// one.
+func toLocalResourceValueMap(in map[string]int64) map[v1.ResourceName]int64 {
+	if in == nil {
+		return nil
+	}
+	out := make(map[v1.ResourceName]int64, len(in))
+	for k, v := range in {
+		out[v1.ResourceName(k)] = v
+	}
+	return out
+}
+
+// toLocalResourceNameMap converts a string-keyed PromQL query map into a v1.ResourceName-keyed
+// one.
+func toLocalResourceNameMap(in map[string]string) map[v1.ResourceName]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[v1.ResourceName]string, len(in))
+	for k, v := range in {
+		out[v1.ResourceName(k)] = v
+	}
+	return out
+}
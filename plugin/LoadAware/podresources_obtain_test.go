@@ -0,0 +1,73 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package LoadAware
+
+import "testing"
+
+// TestFreeContiguousRun covers freeContiguousRun's device-ID-gap logic: unordered IDs,
+// non-numeric IDs (skipped), a single gap splitting the run, and the fully-free/fully-used
+// edge cases.
+func TestFreeContiguousRun(t *testing.T) {
+	tests := []struct {
+		name           string
+		allocatableIDs []string
+		usedIDs        map[string]bool
+		want           int
+	}{
+		{
+			name:           "no allocatable devices",
+			allocatableIDs: nil,
+			want:           0,
+		},
+		{
+			name:           "all devices free forms one run",
+			allocatableIDs: []string{"0", "1", "2", "3"},
+			want:           4,
+		},
+		{
+			name:           "all devices used leaves no run",
+			allocatableIDs: []string{"0", "1", "2"},
+			usedIDs:        map[string]bool{"0": true, "1": true, "2": true},
+			want:           0,
+		},
+		{
+			name:           "a gap splits free devices into shorter runs",
+			allocatableIDs: []string{"0", "1", "2", "3", "4"},
+			usedIDs:        map[string]bool{"2": true},
+			want:           2,
+		},
+		{
+			name:           "unordered IDs are sorted before measuring the run",
+			allocatableIDs: []string{"3", "1", "0", "2"},
+			want:           4,
+		},
+		{
+			name:           "non-numeric IDs are skipped rather than breaking the run",
+			allocatableIDs: []string{"0", "1", "not-a-number", "2"},
+			want:           3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := deviceState{allocatableIDs: tt.allocatableIDs, usedIDs: tt.usedIDs}
+			if got := state.freeContiguousRun(); got != tt.want {
+				t.Errorf("freeContiguousRun() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
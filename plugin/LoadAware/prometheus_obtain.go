@@ -0,0 +1,140 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file adds an optional Prometheus-backed usage source. It periodically evaluates
+// one PromQL expression per resource and caches the per-node result, so that the scoring
+// hot path (score()) never blocks on an HTTP round trip.
+
+package LoadAware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	promodel "github.com/prometheus/common/model"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// defaultPrometheusScrapeInterval is used when no explicit interval is configured.
+const defaultPrometheusScrapeInterval = 30 * time.Second
+
+// prometheusClient periodically evaluates PromQL queries and caches per-node results so
+// score() can read them without making a network call.
+type prometheusClient struct {
+	api            promv1.API
+	nodeLabel      promodel.LabelName
+	queries        map[v1.ResourceName]string
+	scrapeInterval time.Duration
+
+	mu    sync.RWMutex
+	cache map[string]map[v1.ResourceName]int64
+}
+
+// newPrometheusClient builds a client against address. It does not perform any network I/O
+// until run is called. nodeLabel defaults to "node" and scrapeInterval to
+// defaultPrometheusScrapeInterval when zero.
+func newPrometheusClient(address, nodeLabel string, queries map[v1.ResourceName]string, scrapeInterval time.Duration) (*prometheusClient, error) {
+	cli, err := api.NewClient(api.Config{Address: address})
+	if err != nil {
+		return nil, err
+	}
+	if nodeLabel == "" {
+		nodeLabel = "node"
+	}
+	if scrapeInterval <= 0 {
+		scrapeInterval = defaultPrometheusScrapeInterval
+	}
+	return &prometheusClient{
+		api:            promv1.NewAPI(cli),
+		nodeLabel:      promodel.LabelName(nodeLabel),
+		queries:        queries,
+		scrapeInterval: scrapeInterval,
+		cache:          make(map[string]map[v1.ResourceName]int64),
+	}, nil
+}
+
+// run evaluates the configured queries every scrapeInterval until ctx is cancelled. Callers
+// should start it once in a goroutine; it never returns until ctx is done.
+func (p *prometheusClient) run(ctx context.Context) {
+	ticker := time.NewTicker(p.scrapeInterval)
+	defer ticker.Stop()
+
+	p.refresh(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.refresh(ctx)
+		}
+	}
+}
+
+// refresh evaluates every configured query once and repopulates the cache.
+func (p *prometheusClient) refresh(ctx context.Context) {
+	for resource, query := range p.queries {
+		result, warnings, err := p.api.Query(ctx, query, time.Now())
+		if err != nil {
+			klog.InfoS("Prometheus query failed, keeping stale cache", "resource", resource, "err", err)
+			continue
+		}
+		if len(warnings) > 0 {
+			klog.InfoS("Prometheus query returned warnings", "resource", resource, "warnings", warnings)
+		}
+
+		vector, ok := result.(promodel.Vector)
+		if !ok {
+			klog.InfoS("Prometheus query did not return a vector, skipping", "resource", resource)
+			continue
+		}
+
+		for _, sample := range vector {
+			nodeName := string(sample.Metric[p.nodeLabel])
+			if nodeName == "" {
+				continue
+			}
+			p.store(nodeName, resource, int64(sample.Value))
+		}
+	}
+}
+
+func (p *prometheusClient) store(nodeName string, resource v1.ResourceName, value int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	byResource, ok := p.cache[nodeName]
+	if !ok {
+		byResource = make(map[v1.ResourceName]int64)
+		p.cache[nodeName] = byResource
+	}
+	byResource[resource] = value
+}
+
+// get returns the last cached sample for node/resource, and whether one has been scraped yet.
+func (p *prometheusClient) get(nodeName string, resource v1.ResourceName) (int64, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	byResource, ok := p.cache[nodeName]
+	if !ok {
+		return 0, false
+	}
+	value, ok := byResource[resource]
+	return value, ok
+}
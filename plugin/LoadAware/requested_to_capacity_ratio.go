@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This mirrors the upstream RequestedToCapacityRatio scoring strategy (see
+// https://github.com/kubernetes/kubernetes/blob/release-1.19/pkg/scheduler/framework/plugins/noderesources/requested_to_capacity_ratio.go):
+// operators supply a piecewise-linear utilization->score shape, applied to every scored
+// resource, instead of picking between Least/Most, so "prefer 40-60% utilized nodes" is expressible without a
+// new plugin.
+
+package LoadAware
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// maxCustomPriorityScore is the scale shape points are expressed on, matching upstream's
+// convention so operators can reuse NodeResourcesFit shapes verbatim.
+const maxCustomPriorityScore = 10
+
+// UtilizationShapePoint is one point of a piecewise-linear utilization->score function.
+type UtilizationShapePoint struct {
+	// Utilization is a percentage of allocatable, in [0, 100].
+	Utilization int64 `json:"utilization"`
+	// Score is the score assigned at this utilization, on a [0, maxCustomPriorityScore] scale.
+	Score int64 `json:"score"`
+}
+
+// requestedToCapacityRatioScorer builds a scorer that interpolates each resource's
+// configured shape at its current (smoothed+assumed) utilization, then combines the
+// per-resource scores using resToWeightMap and rescales the result onto the framework's
+// [0, MaxNodeScore] range.
+func requestedToCapacityRatioScorer(resToWeightMap resourceToWeightMap, shapes map[v1.ResourceName][]UtilizationShapePoint) func(requested, allocatable, smoothed resourceToValueMap) int64 {
+	return func(requested, allocatable, smoothed resourceToValueMap) int64 {
+		var weightedSum, weightSum int64
+		for resource, weight := range resToWeightMap {
+			points := shapes[resource]
+			if len(points) == 0 {
+				continue
+			}
+			utilization := int64(fractionOfCapacity(effectiveUsage(requested, smoothed, resource), allocatable[resource]) * 100)
+			weightedSum += shapeScore(points, utilization) * weight
+			weightSum += weight
+		}
+		if weightSum == 0 {
+			return 0
+		}
+		return (weightedSum / weightSum) * (int64(maxUtilization) / maxCustomPriorityScore)
+	}
+}
+
+// shapeScore linearly interpolates points at utilization, clamping to the first/last
+// point's score outside the configured range. points need not be pre-sorted.
+func shapeScore(points []UtilizationShapePoint, utilization int64) int64 {
+	sorted := make([]UtilizationShapePoint, len(points))
+	copy(sorted, points)
+	for i := 1; i < len(sorted); i++ {
+		for j := i; j > 0 && sorted[j].Utilization < sorted[j-1].Utilization; j-- {
+			sorted[j], sorted[j-1] = sorted[j-1], sorted[j]
+		}
+	}
+
+	if utilization <= sorted[0].Utilization {
+		return sorted[0].Score
+	}
+	last := sorted[len(sorted)-1]
+	if utilization >= last.Utilization {
+		return last.Score
+	}
+
+	for i := 1; i < len(sorted); i++ {
+		lo, hi := sorted[i-1], sorted[i]
+		if utilization > hi.Utilization {
+			continue
+		}
+		if hi.Utilization == lo.Utilization {
+			return hi.Score
+		}
+		return lo.Score + (hi.Score-lo.Score)*(utilization-lo.Utilization)/(hi.Utilization-lo.Utilization)
+	}
+	return last.Score
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package LoadAware
+
+import "testing"
+
+// TestShapeScore covers shapeScore's sort/clamp/interpolation behavior: unsorted input
+// points, utilization outside the configured range (clamped to the endpoints), utilization
+// exactly on a point, and interpolation strictly between two points.
+func TestShapeScore(t *testing.T) {
+	tests := []struct {
+		name        string
+		points      []UtilizationShapePoint
+		utilization int64
+		want        int64
+	}{
+		{
+			name:        "unsorted input is sorted before interpolating",
+			points:      []UtilizationShapePoint{{Utilization: 100, Score: 0}, {Utilization: 0, Score: 10}, {Utilization: 50, Score: 7}},
+			utilization: 50,
+			want:        7,
+		},
+		{
+			name:        "utilization below range clamps to first point",
+			points:      []UtilizationShapePoint{{Utilization: 0, Score: 10}, {Utilization: 100, Score: 0}},
+			utilization: -10,
+			want:        10,
+		},
+		{
+			name:        "utilization above range clamps to last point",
+			points:      []UtilizationShapePoint{{Utilization: 0, Score: 10}, {Utilization: 100, Score: 0}},
+			utilization: 150,
+			want:        0,
+		},
+		{
+			name:        "utilization exactly on a middle point",
+			points:      []UtilizationShapePoint{{Utilization: 0, Score: 10}, {Utilization: 50, Score: 7}, {Utilization: 100, Score: 0}},
+			utilization: 50,
+			want:        7,
+		},
+		{
+			name:        "interpolates strictly between two points",
+			points:      []UtilizationShapePoint{{Utilization: 0, Score: 10}, {Utilization: 100, Score: 0}},
+			utilization: 25,
+			want:        7,
+		},
+		{
+			name:        "single point returns its score regardless of utilization",
+			points:      []UtilizationShapePoint{{Utilization: 50, Score: 5}},
+			utilization: 90,
+			want:        5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shapeScore(tt.points, tt.utilization); got != tt.want {
+				t.Errorf("shapeScore(%v, %d) = %d, want %d", tt.points, tt.utilization, got, tt.want)
+			}
+		})
+	}
+}
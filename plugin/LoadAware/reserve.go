@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package LoadAware
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+var _ framework.ReservePlugin = &Allocatable{}
+var _ framework.FilterPlugin = &Allocatable{}
+
+// Reserve records pod's request against nodeName in the assumed-load cache, so that other
+// pods scheduled before the next metrics scrape see this node as already a little busier.
+func (alloc *Allocatable) Reserve(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) *framework.Status {
+	if alloc.assumedLoad == nil {
+		return nil
+	}
+	alloc.assumedLoad.add(nodeName, string(pod.UID), podResourceRequests(pod), time.Now())
+	return nil
+}
+
+// Unreserve undoes Reserve, e.g. when a later plugin rejects the pod or binding fails.
+func (alloc *Allocatable) Unreserve(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) {
+	if alloc.assumedLoad == nil {
+		return
+	}
+	alloc.assumedLoad.remove(nodeName, string(pod.UID))
+}
+
+// Filter rejects nodes whose projected utilization (metrics/Prometheus usage plus assumed
+// load from in-flight reservations) exceeds a configured hard cap for any resource.
+func (alloc *Allocatable) Filter(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	if len(alloc.hardCaps) == 0 {
+		return nil
+	}
+	node := nodeInfo.Node()
+	if node == nil {
+		return framework.NewStatus(framework.Error, "node not found")
+	}
+
+	assumed := alloc.assumedLoadFor(node.Name)
+	reclaimed, usesReclaimed := alloc.reclaimedMappingFor(pod)
+	for resource, hardCap := range alloc.hardCaps {
+		allocatable, used := alloc.calculateResourceAllocatableCost(nodeInfo, resource, reclaimed, usesReclaimed)
+		if allocatable == 0 {
+			continue
+		}
+		projected := used + assumed[resource]
+		if projected*100/allocatable > hardCap {
+			return framework.NewStatus(framework.Unschedulable,
+				fmt.Sprintf("node %q projected %s utilization %d%% exceeds hard cap %d%%", node.Name, resource, projected*100/allocatable, hardCap))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file implements the Least/Most/Balanced scoring strategies on top of
+// resourceAllocationScorer. It mirrors the upstream noderesources plugins
+// (least_allocated.go, most_allocated.go, balanced_allocation.go) but is
+// expressed as scorer funcs selectable at runtime via
+// LoadAwareArgs.ScoringStrategy.Type, since this plugin exposes all of its
+// strategies (these three and RequestedToCapacityRatio) under a single plugin name.
+
+package LoadAware
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+const maxUtilization = framework.MaxNodeScore
+
+// leastResourceScorer favors nodes with fewer requested resources. Each resource
+// is scored on its smoothed usage, or on the raw value when no smoothed sample is
+// available; both already include assumed load, so freshly scheduled pods which
+// metrics haven't caught up with still count.
+func leastResourceScorer(resToWeightMap resourceToWeightMap) func(requested, allocatable, smoothed resourceToValueMap) int64 {
+	return func(requested, allocatable, smoothed resourceToValueMap) int64 {
+		var nodeScore, weightSum int64
+		for resource, weight := range resToWeightMap {
+			resourceScore := leastRequestedScore(effectiveUsage(requested, smoothed, resource), allocatable[resource])
+			nodeScore += resourceScore * weight
+			weightSum += weight
+		}
+		if weightSum == 0 {
+			return 0
+		}
+		return nodeScore / weightSum
+	}
+}
+
+// mostResourceScorer favors nodes with more requested resources, i.e. it
+// packs pods onto already-busy nodes to leave other nodes free.
+func mostResourceScorer(resToWeightMap resourceToWeightMap) func(requested, allocatable, smoothed resourceToValueMap) int64 {
+	return func(requested, allocatable, smoothed resourceToValueMap) int64 {
+		var nodeScore, weightSum int64
+		for resource, weight := range resToWeightMap {
+			resourceScore := mostRequestedScore(effectiveUsage(requested, smoothed, resource), allocatable[resource])
+			nodeScore += resourceScore * weight
+			weightSum += weight
+		}
+		if weightSum == 0 {
+			return 0
+		}
+		return nodeScore / weightSum
+	}
+}
+
+// balancedResourceScorer favors nodes whose cpu and memory utilization are
+// close to each other, to avoid nodes with lots of free cpu but little free
+// memory (or vice versa).
+func balancedResourceScorer(resToWeightMap resourceToWeightMap) func(requested, allocatable, smoothed resourceToValueMap) int64 {
+	return func(requested, allocatable, smoothed resourceToValueMap) int64 {
+		cpuFraction := fractionOfCapacity(effectiveUsage(requested, smoothed, v1.ResourceCPU), allocatable[v1.ResourceCPU])
+		memFraction := fractionOfCapacity(effectiveUsage(requested, smoothed, v1.ResourceMemory), allocatable[v1.ResourceMemory])
+		if cpuFraction >= 1 || memFraction >= 1 {
+			return 0
+		}
+		diff := cpuFraction - memFraction
+		if diff < 0 {
+			diff = -diff
+		}
+		return int64((1 - diff) * float64(maxUtilization))
+	}
+}
+
+// leastRequestedScore calculates the score based on the least requested bytes/milli-cpu.
+func leastRequestedScore(used, capacity int64) int64 {
+	if capacity == 0 {
+		return 0
+	}
+	if used > capacity {
+		return 0
+	}
+	return ((capacity - used) * int64(maxUtilization)) / capacity
+}
+
+// mostRequestedScore calculates the score based on the most requested bytes/milli-cpu.
+func mostRequestedScore(used, capacity int64) int64 {
+	if capacity == 0 {
+		return 0
+	}
+	if used > capacity {
+		return 0
+	}
+	return (used * int64(maxUtilization)) / capacity
+}
+
+func fractionOfCapacity(used, capacity int64) float64 {
+	if capacity == 0 {
+		return 1
+	}
+	return float64(used) / float64(capacity)
+}